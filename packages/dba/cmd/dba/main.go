@@ -2,7 +2,6 @@
 package main
 
 import (
-	"fmt"
 	"os"
 
 	"github.com/dba-cli/dba/internal/cli"
@@ -29,7 +28,7 @@ func main() {
 	}
 
 	if err := cli.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		cli.OutputError(err)
+		os.Exit(cli.GetExitCode(err))
 	}
 }
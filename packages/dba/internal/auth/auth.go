@@ -0,0 +1,104 @@
+// internal/auth/auth.go
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// Credentials is what `dba auth login` persists to disk: an API token
+// plus the team it was issued for.
+type Credentials struct {
+	Token string `json:"token"`
+	Team  string `json:"team"`
+}
+
+// ErrNotLoggedIn is returned when no credentials file exists yet, or
+// when it lacks the requested team or token.
+var ErrNotLoggedIn = errors.New("not logged in")
+
+// GetTeamSlug resolves the team to operate against: the DBA_TEAM
+// environment variable takes precedence (for CI and scripting), falling
+// back to the team saved by the last `dba auth login`.
+func GetTeamSlug() (string, error) {
+	if team := os.Getenv("DBA_TEAM"); team != "" {
+		return team, nil
+	}
+
+	creds, err := loadCredentials()
+	if err != nil {
+		return "", err
+	}
+	if creds.Team == "" {
+		return "", ErrNotLoggedIn
+	}
+	return creds.Team, nil
+}
+
+// GetToken resolves the API token to authenticate with: the DBA_TOKEN
+// environment variable takes precedence, falling back to the token
+// saved by the last `dba auth login`.
+func GetToken() (string, error) {
+	if token := os.Getenv("DBA_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	creds, err := loadCredentials()
+	if err != nil {
+		return "", err
+	}
+	if creds.Token == "" {
+		return "", ErrNotLoggedIn
+	}
+	return creds.Token, nil
+}
+
+// SaveCredentials persists creds to the per-user config file, creating
+// its parent directory if needed.
+func SaveCredentials(creds Credentials) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+func loadCredentials() (Credentials, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Credentials{}, ErrNotLoggedIn
+		}
+		return Credentials{}, err
+	}
+
+	var creds Credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return Credentials{}, err
+	}
+	return creds, nil
+}
+
+func credentialsPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "dba", "credentials.json"), nil
+}
@@ -0,0 +1,130 @@
+// internal/browser/browser.go
+package browser
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+)
+
+// Options configures how a headless Chrome instance is launched or
+// attached to for a Session.
+type Options struct {
+	// RemoteDebuggingURL attaches to an already-running Chrome instance
+	// (e.g. one exposed by a sandbox) instead of launching a local one.
+	RemoteDebuggingURL string
+	// Headless controls whether a locally-launched Chrome runs headless.
+	// Ignored when RemoteDebuggingURL is set.
+	Headless bool
+	// Timeout bounds how long the session, including every step run
+	// against it, may take.
+	Timeout time.Duration
+}
+
+// Session wraps a chromedp browser context along with the teardown funcs
+// needed to release it.
+type Session struct {
+	ctx     context.Context
+	cancels []context.CancelFunc
+}
+
+// New starts (or attaches to) a headless Chrome instance per opts.
+func New(opts Options) (*Session, error) {
+	var allocCtx context.Context
+	var cancels []context.CancelFunc
+
+	if opts.RemoteDebuggingURL != "" {
+		c, cancel := chromedp.NewRemoteAllocator(context.Background(), opts.RemoteDebuggingURL)
+		allocCtx, cancels = c, append(cancels, cancel)
+	} else {
+		execOpts := append(append([]chromedp.ExecAllocatorOption{}, chromedp.DefaultExecAllocatorOptions[:]...),
+			chromedp.Flag("headless", opts.Headless),
+		)
+		c, cancel := chromedp.NewExecAllocator(context.Background(), execOpts...)
+		allocCtx, cancels = c, append(cancels, cancel)
+	}
+
+	ctx, cancel := chromedp.NewContext(allocCtx)
+	cancels = append(cancels, cancel)
+
+	if opts.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		cancels = append(cancels, cancel)
+	}
+
+	return &Session{ctx: ctx, cancels: cancels}, nil
+}
+
+// Close releases every resource associated with the session.
+func (s *Session) Close() {
+	for i := len(s.cancels) - 1; i >= 0; i-- {
+		s.cancels[i]()
+	}
+}
+
+// Open navigates to url and waits for the page to finish loading.
+func (s *Session) Open(url string) error {
+	return chromedp.Run(s.ctx, chromedp.Navigate(url))
+}
+
+// Screenshot navigates to url and captures a screenshot, optionally of
+// the full scrollable page rather than just the viewport.
+func (s *Session) Screenshot(url string, fullPage bool) ([]byte, error) {
+	var buf []byte
+
+	actions := []chromedp.Action{chromedp.Navigate(url)}
+	if fullPage {
+		// Quality 100 makes chromedp emit PNG, like CaptureScreenshot.
+		actions = append(actions, chromedp.FullScreenshot(&buf, 100))
+	} else {
+		actions = append(actions, chromedp.CaptureScreenshot(&buf))
+	}
+
+	if err := chromedp.Run(s.ctx, actions...); err != nil {
+		return nil, fmt.Errorf("screenshot failed: %w", err)
+	}
+	return buf, nil
+}
+
+// PDF navigates to url and renders the page to a PDF.
+func (s *Session) PDF(url string) ([]byte, error) {
+	var buf []byte
+
+	err := chromedp.Run(s.ctx,
+		chromedp.Navigate(url),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			data, _, err := page.PrintToPDF().Do(ctx)
+			if err != nil {
+				return err
+			}
+			buf = data
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("pdf render failed: %w", err)
+	}
+	return buf, nil
+}
+
+// Eval navigates to url and evaluates expr, returning its
+// JSON-serializable result. expr is wrapped in an IIFE so that a
+// function body using `return`, like the dba browser eval CLI expects,
+// is valid JS rather than a syntax error at the top level.
+func (s *Session) Eval(url, expr string) (any, error) {
+	var result any
+
+	wrapped := fmt.Sprintf("(function() { %s })()", expr)
+
+	err := chromedp.Run(s.ctx,
+		chromedp.Navigate(url),
+		chromedp.Evaluate(wrapped, &result),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("eval failed: %w", err)
+	}
+	return result, nil
+}
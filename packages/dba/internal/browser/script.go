@@ -0,0 +1,85 @@
+// internal/browser/script.go
+package browser
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/chromedp/chromedp"
+)
+
+// Step is one action in a browser script, parsed from YAML. Exactly one
+// field should be set per step.
+type Step struct {
+	Navigate    string      `yaml:"navigate,omitempty"`
+	WaitVisible string      `yaml:"wait_visible,omitempty"`
+	Click       string      `yaml:"click,omitempty"`
+	Type        *TypeStep   `yaml:"type,omitempty"`
+	Screenshot  string      `yaml:"screenshot,omitempty"`
+	AssertText  *AssertStep `yaml:"assert_text,omitempty"`
+}
+
+// TypeStep types Text into the element matched by Selector.
+type TypeStep struct {
+	Selector string `yaml:"selector"`
+	Text     string `yaml:"text"`
+}
+
+// AssertStep fails the script if the text content of Selector doesn't
+// contain Contains.
+type AssertStep struct {
+	Selector string `yaml:"selector"`
+	Contains string `yaml:"contains"`
+}
+
+// RunScript executes steps against the session in order, returning an
+// error (naming the failing step) as soon as one fails.
+func (s *Session) RunScript(steps []Step) error {
+	for i, step := range steps {
+		if err := s.runStep(step); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (s *Session) runStep(step Step) error {
+	switch {
+	case step.Navigate != "":
+		return chromedp.Run(s.ctx, chromedp.Navigate(step.Navigate))
+
+	case step.WaitVisible != "":
+		return chromedp.Run(s.ctx, chromedp.WaitVisible(step.WaitVisible, chromedp.ByQuery))
+
+	case step.Click != "":
+		return chromedp.Run(s.ctx, chromedp.Click(step.Click, chromedp.ByQuery))
+
+	case step.Type != nil:
+		return chromedp.Run(s.ctx, chromedp.SendKeys(step.Type.Selector, step.Type.Text, chromedp.ByQuery))
+
+	case step.Screenshot != "":
+		var buf []byte
+		if err := chromedp.Run(s.ctx, chromedp.CaptureScreenshot(&buf)); err != nil {
+			return err
+		}
+		return writeFile(step.Screenshot, buf)
+
+	case step.AssertText != nil:
+		var text string
+		if err := chromedp.Run(s.ctx, chromedp.Text(step.AssertText.Selector, &text, chromedp.ByQuery)); err != nil {
+			return err
+		}
+		if !strings.Contains(text, step.AssertText.Contains) {
+			return fmt.Errorf("assert_text: %q does not contain %q", text, step.AssertText.Contains)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("empty or unrecognized step")
+	}
+}
+
+func writeFile(path string, data []byte) error {
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,58 @@
+// internal/browser/script_test.go
+package browser
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestStepYAML(t *testing.T) {
+	const doc = `
+- navigate: https://example.com
+- wait_visible: h1
+- type:
+    selector: input[name=q]
+    text: hello
+- click: button[type=submit]
+- assert_text:
+    selector: h1
+    contains: Results
+- screenshot: result.png
+`
+
+	var steps []Step
+	if err := yaml.Unmarshal([]byte(doc), &steps); err != nil {
+		t.Fatalf("yaml.Unmarshal: %v", err)
+	}
+
+	if len(steps) != 6 {
+		t.Fatalf("got %d steps, want 6", len(steps))
+	}
+
+	if steps[0].Navigate != "https://example.com" {
+		t.Errorf("steps[0].Navigate = %q, want %q", steps[0].Navigate, "https://example.com")
+	}
+	if steps[1].WaitVisible != "h1" {
+		t.Errorf("steps[1].WaitVisible = %q, want %q", steps[1].WaitVisible, "h1")
+	}
+	if steps[2].Type == nil || steps[2].Type.Selector != "input[name=q]" || steps[2].Type.Text != "hello" {
+		t.Errorf("steps[2].Type = %+v, want {input[name=q] hello}", steps[2].Type)
+	}
+	if steps[3].Click != "button[type=submit]" {
+		t.Errorf("steps[3].Click = %q, want %q", steps[3].Click, "button[type=submit]")
+	}
+	if steps[4].AssertText == nil || steps[4].AssertText.Selector != "h1" || steps[4].AssertText.Contains != "Results" {
+		t.Errorf("steps[4].AssertText = %+v, want {h1 Results}", steps[4].AssertText)
+	}
+	if steps[5].Screenshot != "result.png" {
+		t.Errorf("steps[5].Screenshot = %q, want %q", steps[5].Screenshot, "result.png")
+	}
+}
+
+func TestRunStep_UnrecognizedStep(t *testing.T) {
+	s := &Session{}
+	if err := s.runStep(Step{}); err == nil {
+		t.Error("expected an error for an empty/unrecognized step, got nil")
+	}
+}
@@ -0,0 +1,259 @@
+// internal/cli/browser.go
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image/png"
+	"os"
+	"time"
+
+	"github.com/dba-cli/dba/internal/auth"
+	"github.com/dba-cli/dba/internal/browser"
+	"github.com/dba-cli/dba/internal/vm"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var browserFlagRemoteDebugURL string
+
+var browserCmd = &cobra.Command{
+	Use:   "browser",
+	Short: "Drive headless Chrome against sandbox VNC/desktop URLs",
+	Long: `Browser automation against the URLs a VM exposes.
+
+By default a local headless Chrome is launched for each command. Pass
+--remote-debugging-url to attach to Chrome already running inside a
+sandbox instead.`,
+}
+
+var browserOpenCmd = &cobra.Command{
+	Use:   "open <id> <url>",
+	Short: "Navigate to a URL",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, sess, err := newBrowserSession(args[0])
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		if err := sess.Open(args[1]); err != nil {
+			return fmt.Errorf("failed to open %s: %w", args[1], err)
+		}
+		fmt.Printf("Opened %s\n", args[1])
+		return nil
+	},
+}
+
+var (
+	browserScreenshotFlagOut      string
+	browserScreenshotFlagFullPage bool
+)
+
+var browserScreenshotCmd = &cobra.Command{
+	Use:   "screenshot <id> <url>",
+	Short: "Capture a screenshot",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if browserScreenshotFlagOut == "" {
+			return &CLIError{Code: "invalid_flag", ExitCode: ExitUsage, Message: "--out is required"}
+		}
+
+		_, sess, err := newBrowserSession(args[0])
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		data, err := sess.Screenshot(args[1], browserScreenshotFlagFullPage)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(browserScreenshotFlagOut, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", browserScreenshotFlagOut, err)
+		}
+
+		width, height := 0, 0
+		if cfg, err := png.DecodeConfig(bytes.NewReader(data)); err == nil {
+			width, height = cfg.Width, cfg.Height
+		}
+
+		if flagJSON {
+			envelope, _ := json.Marshal(map[string]any{
+				"path":   browserScreenshotFlagOut,
+				"width":  width,
+				"height": height,
+				"bytes":  len(data),
+			})
+			fmt.Println(string(envelope))
+			return nil
+		}
+
+		fmt.Printf("Saved screenshot to %s (%dx%d, %d bytes)\n", browserScreenshotFlagOut, width, height, len(data))
+		return nil
+	},
+}
+
+var browserPDFFlagOut string
+
+var browserPDFCmd = &cobra.Command{
+	Use:   "pdf <id> <url>",
+	Short: "Render a page to PDF",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if browserPDFFlagOut == "" {
+			return &CLIError{Code: "invalid_flag", ExitCode: ExitUsage, Message: "--out is required"}
+		}
+
+		_, sess, err := newBrowserSession(args[0])
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		data, err := sess.PDF(args[1])
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(browserPDFFlagOut, data, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", browserPDFFlagOut, err)
+		}
+
+		if flagJSON {
+			out, _ := json.Marshal(map[string]any{"path": browserPDFFlagOut, "bytes": len(data)})
+			fmt.Println(string(out))
+			return nil
+		}
+
+		fmt.Printf("Saved PDF to %s (%d bytes)\n", browserPDFFlagOut, len(data))
+		return nil
+	},
+}
+
+var browserEvalCmd = &cobra.Command{
+	Use:   "eval <id> <url> <expr>",
+	Short: "Evaluate a JS function body and print the value it returns",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, sess, err := newBrowserSession(args[0])
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		result, err := sess.Eval(args[1], args[2])
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+var browserScriptCmd = &cobra.Command{
+	Use:   "script <id> <file.yaml>",
+	Short: "Run a sequence of browser steps from a YAML file",
+	Long: `Run a sequence of browser steps from a YAML file. Each step is one
+of: navigate, wait_visible, click, type, screenshot, assert_text.
+
+Example:
+  - navigate: https://example.com
+  - wait_visible: h1
+  - type:
+      selector: input[name=q]
+      text: hello
+  - click: button[type=submit]
+  - assert_text:
+      selector: h1
+      contains: Results
+  - screenshot: result.png`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(args[1])
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", args[1], err)
+		}
+
+		var steps []browser.Step
+		if err := yaml.Unmarshal(data, &steps); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", args[1], err)
+		}
+
+		_, sess, err := newBrowserSession(args[0])
+		if err != nil {
+			return err
+		}
+		defer sess.Close()
+
+		if err := sess.RunScript(steps); err != nil {
+			return fmt.Errorf("script %s failed: %w", args[1], err)
+		}
+		fmt.Printf("Ran %d steps from %s\n", len(steps), args[1])
+		return nil
+	},
+}
+
+func init() {
+	browserCmd.PersistentFlags().StringVar(&browserFlagRemoteDebugURL, "remote-debugging-url", "", "Attach to Chrome already running inside the sandbox instead of launching one locally")
+
+	browserScreenshotCmd.Flags().StringVar(&browserScreenshotFlagOut, "out", "", "Path to write the screenshot to (required)")
+	browserScreenshotCmd.Flags().BoolVar(&browserScreenshotFlagFullPage, "full-page", false, "Capture the full scrollable page instead of just the viewport")
+
+	browserPDFCmd.Flags().StringVar(&browserPDFFlagOut, "out", "", "Path to write the PDF to (required)")
+
+	browserCmd.AddCommand(browserOpenCmd)
+	browserCmd.AddCommand(browserScreenshotCmd)
+	browserCmd.AddCommand(browserPDFCmd)
+	browserCmd.AddCommand(browserEvalCmd)
+	browserCmd.AddCommand(browserScriptCmd)
+
+	rootCmd.AddCommand(browserCmd)
+}
+
+// newBrowserSession validates rawTarget against the user's instances (so
+// typos and instances you don't own fail fast, the same as every other
+// command) and starts a browser session per the --remote-debugging-url flag.
+func newBrowserSession(rawTarget string) (*vm.Instance, *browser.Session, error) {
+	t := parseTarget(rawTarget)
+
+	teamSlug, err := auth.GetTeamSlug()
+	if err != nil {
+		return nil, nil, ErrTeamNotSet(err)
+	}
+
+	client, err := vm.NewClient()
+	if err != nil {
+		return nil, nil, ErrNotAuthenticated(err)
+	}
+	client.SetTeamSlug(teamSlug)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	instance, err := client.GetInstance(ctx, t.InstanceID)
+	cancel()
+	if err != nil {
+		return nil, nil, wrapInstanceErr(t.InstanceID, err)
+	}
+
+	if _, err := resolveAgent(instance, t); err != nil {
+		return nil, nil, err
+	}
+
+	sess, err := browser.New(browser.Options{
+		RemoteDebuggingURL: browserFlagRemoteDebugURL,
+		Headless:           true,
+		Timeout:            2 * time.Minute,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to start browser: %w", err)
+	}
+
+	return instance, sess, nil
+}
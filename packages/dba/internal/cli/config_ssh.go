@@ -0,0 +1,316 @@
+// internal/cli/config_ssh.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dba-cli/dba/internal/auth"
+	"github.com/dba-cli/dba/internal/vm"
+	"github.com/spf13/cobra"
+)
+
+const (
+	sshConfigBeginMarker = "# BEGIN DBA MANAGED BLOCK"
+	sshConfigEndMarker   = "# END DBA MANAGED BLOCK"
+)
+
+var (
+	configSSHFlagRemove bool
+	configSSHFlagDryRun bool
+	configSSHFlagYes    bool
+	configSSHFlagPath   string
+	configSSHFlagWS     bool
+)
+
+var configSSHCmd = &cobra.Command{
+	Use:   "config-ssh",
+	Short: "Manage an OpenSSH client config block for dba VMs",
+	Long: `Install or remove a managed block in ~/.ssh/config so that plain
+'ssh dba_abc123' (and scp/rsync/etc.) work against dba VMs without any
+extra flags.
+
+Installing fetches your current VMs (and their agents) and writes one
+Host stanza per target with its real HostName and auth token already
+filled in, so 'ssh dba_abc123' and 'ssh dba_abc123.web' just work.
+
+Examples:
+  dba config-ssh                      # install/update the managed block
+  dba config-ssh --dry-run            # print the block without writing it
+  dba config-ssh --yes                # skip the confirmation prompt
+  dba config-ssh --ssh-config ~/.ssh/config.d/dba
+  dba config-ssh --ws                 # route each Host through 'dba tunnel --stdio'
+  dba config-ssh --remove             # remove the managed block`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, err := sshConfigPath()
+		if err != nil {
+			return fmt.Errorf("failed to locate ssh config: %w", err)
+		}
+
+		if configSSHFlagRemove {
+			if configSSHFlagDryRun {
+				withoutBlock, err := readSSHConfigWithoutBlock(path)
+				if err != nil {
+					return fmt.Errorf("failed to read ssh config: %w", err)
+				}
+				fmt.Print(withoutBlock)
+				return nil
+			}
+			if err := confirmSSHConfigWrite(fmt.Sprintf("Remove the dba block from %s?", path)); err != nil {
+				return err
+			}
+			if err := rewriteSSHConfigLocked(path, func(withoutBlock string) string {
+				return withoutBlock
+			}); err != nil {
+				return fmt.Errorf("failed to remove ssh config block: %w", err)
+			}
+			fmt.Printf("Removed dba block from %s\n", path)
+			return nil
+		}
+
+		block, err := buildSSHConfigBlock()
+		if err != nil {
+			return err
+		}
+
+		if configSSHFlagDryRun {
+			fmt.Print(block)
+			return nil
+		}
+
+		if err := confirmSSHConfigWrite(fmt.Sprintf("Write dba Host entries to %s?", path)); err != nil {
+			return err
+		}
+
+		if err := rewriteSSHConfigLocked(path, func(withoutBlock string) string {
+			var out strings.Builder
+			out.WriteString(withoutBlock)
+			if withoutBlock != "" {
+				out.WriteString("\n")
+			}
+			out.WriteString(block)
+			return out.String()
+		}); err != nil {
+			return fmt.Errorf("failed to write ssh config block: %w", err)
+		}
+		fmt.Printf("Installed dba block in %s\n", path)
+		return nil
+	},
+}
+
+func init() {
+	configSSHCmd.Flags().BoolVar(&configSSHFlagRemove, "remove", false, "Remove the managed block instead of installing it")
+	configSSHCmd.Flags().BoolVar(&configSSHFlagDryRun, "dry-run", false, "Print the resulting block without writing anything")
+	configSSHCmd.Flags().BoolVar(&configSSHFlagYes, "yes", false, "Skip the confirmation prompt")
+	configSSHCmd.Flags().StringVar(&configSSHFlagPath, "ssh-config", "", "Path to the ssh config file to manage (default ~/.ssh/config)")
+	configSSHCmd.Flags().BoolVar(&configSSHFlagWS, "ws", false, "Route each Host through 'dba tunnel --stdio' (for networks that block port 22)")
+	rootCmd.AddCommand(configSSHCmd)
+}
+
+func sshConfigPath() (string, error) {
+	if configSSHFlagPath != "" {
+		return configSSHFlagPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".ssh", "config"), nil
+}
+
+// confirmSSHConfigWrite asks the user to confirm before touching their
+// ssh config, unless --yes was passed.
+func confirmSSHConfigWrite(prompt string) error {
+	if configSSHFlagYes {
+		return nil
+	}
+
+	fmt.Printf("%s [y/N] ", prompt)
+	var reply string
+	fmt.Scanln(&reply)
+	reply = strings.ToLower(strings.TrimSpace(reply))
+	if reply != "y" && reply != "yes" {
+		return &CLIError{Code: "aborted", ExitCode: ExitError, Message: "aborted, ssh config left unchanged"}
+	}
+	return nil
+}
+
+// buildSSHConfigBlock enumerates the caller's instances (and their
+// agents) and renders one Host stanza per target, each with the real
+// HostName and User (the per-target auth token) filled in from
+// GetSSHCredentials, so a bare 'ssh dba_abc123' can authenticate.
+func buildSSHConfigBlock() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	teamSlug, err := auth.GetTeamSlug()
+	if err != nil {
+		return "", ErrTeamNotSet(err)
+	}
+
+	client, err := vm.NewClient()
+	if err != nil {
+		return "", ErrNotAuthenticated(err)
+	}
+	client.SetTeamSlug(teamSlug)
+
+	instances, err := client.ListInstances(ctx)
+	if err != nil {
+		return "", ErrAPIUnavailable(err)
+	}
+
+	var b strings.Builder
+	b.WriteString(sshConfigBeginMarker + "\n")
+	b.WriteString("# Managed by `dba config-ssh` - do not edit by hand.\n")
+
+	for _, inst := range instances {
+		if len(inst.Agents) == 0 {
+			if err := writeSSHHostStanza(&b, ctx, client, inst.ID, inst.ID, ""); err != nil {
+				return "", err
+			}
+			continue
+		}
+		for _, agent := range inst.Agents {
+			host := inst.ID + "." + agent.Name
+			if err := writeSSHHostStanza(&b, ctx, client, host, inst.ID, agent.Name); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	b.WriteString(sshConfigEndMarker + "\n")
+	return b.String(), nil
+}
+
+// writeSSHHostStanza fetches real SSH credentials for instanceID (and,
+// if non-empty, agentName) and appends a Host stanza for host to b.
+func writeSSHHostStanza(b *strings.Builder, ctx context.Context, client *vm.Client, host, instanceID, agentName string) error {
+	sshCommand, err := client.GetSSHCredentials(ctx, instanceID, agentName)
+	if err != nil {
+		return wrapInstanceErr(instanceID, err)
+	}
+
+	parts := strings.Fields(sshCommand)
+	if len(parts) < 2 {
+		return &CLIError{Code: "invalid_ssh_response", ExitCode: ExitError, Message: "invalid SSH command format"}
+	}
+
+	userHost := parts[len(parts)-1]
+	user, hostname, ok := strings.Cut(userHost, "@")
+	if !ok {
+		return &CLIError{Code: "invalid_ssh_response", ExitCode: ExitError, Message: "invalid SSH command format"}
+	}
+
+	fmt.Fprintf(b, "Host %s\n", host)
+	fmt.Fprintf(b, "  HostName %s\n", hostname)
+	fmt.Fprintf(b, "  User %s\n", user)
+	b.WriteString("  StrictHostKeyChecking no\n")
+	b.WriteString("  UserKnownHostsFile /dev/null\n")
+	if configSSHFlagWS {
+		fmt.Fprintf(b, "  ProxyCommand %s\n", tunnelProxyCommand(host))
+	}
+	return nil
+}
+
+// rewriteSSHConfigLocked takes an exclusive lock on path (so two
+// concurrent `dba config-ssh` runs can't interleave writes), backs up
+// the existing file to path+".dba.bak", and replaces its contents with
+// render(withoutExistingBlock).
+func rewriteSSHConfigLocked(path string, render func(withoutBlock string) string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	unlock, err := acquireSSHConfigLock(path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	withoutBlock, err := readSSHConfigWithoutBlock(path)
+	if err != nil {
+		return err
+	}
+
+	mode := os.FileMode(0o600)
+	if info, statErr := os.Stat(path); statErr == nil {
+		mode = info.Mode()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path+".dba.bak", data, mode); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+
+	return os.WriteFile(path, []byte(render(withoutBlock)), mode)
+}
+
+const sshConfigLockTimeout = 5 * time.Second
+
+// acquireSSHConfigLock takes an exclusive lock on lockPath so two
+// concurrent `dba config-ssh` runs can't interleave writes. It uses a
+// plain exclusive-create lockfile rather than syscall.Flock, which
+// doesn't exist on Windows, so this builds and runs on every platform
+// dba supports. The returned func releases the lock.
+func acquireSSHConfigLock(lockPath string) (func(), error) {
+	deadline := time.Now().Add(sshConfigLockTimeout)
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(lockPath) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock file %s: %w", lockPath, err)
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock %s (another config-ssh run in progress?)", lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// readSSHConfigWithoutBlock reads the config at path (tolerating a missing
+// file) and returns its contents with any existing dba managed block
+// stripped out.
+func readSSHConfigWithoutBlock(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	var kept []string
+	inBlock := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == sshConfigBeginMarker:
+			inBlock = true
+			continue
+		case trimmed == sshConfigEndMarker:
+			inBlock = false
+			continue
+		case inBlock:
+			continue
+		default:
+			kept = append(kept, line)
+		}
+	}
+
+	withoutBlock := strings.TrimRight(strings.Join(kept, "\n"), "\n")
+	if withoutBlock != "" {
+		withoutBlock += "\n"
+	}
+	return withoutBlock, nil
+}
@@ -0,0 +1,61 @@
+// internal/cli/config_ssh_test.go
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSSHConfigWithoutBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	contents := "Host example.com\n  User alice\n" +
+		sshConfigBeginMarker + "\n" +
+		"Host dba_abc123\n  HostName 1.2.3.4\n" +
+		sshConfigEndMarker + "\n" +
+		"Host another\n  User bob\n"
+
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := readSSHConfigWithoutBlock(path)
+	if err != nil {
+		t.Fatalf("readSSHConfigWithoutBlock: %v", err)
+	}
+
+	want := "Host example.com\n  User alice\nHost another\n  User bob\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadSSHConfigWithoutBlock_NoExistingBlock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config")
+
+	contents := "Host example.com\n  User alice\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	got, err := readSSHConfigWithoutBlock(path)
+	if err != nil {
+		t.Fatalf("readSSHConfigWithoutBlock: %v", err)
+	}
+	if got != contents {
+		t.Errorf("got %q, want %q", got, contents)
+	}
+}
+
+func TestReadSSHConfigWithoutBlock_MissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "does-not-exist")
+
+	got, err := readSSHConfigWithoutBlock(path)
+	if err != nil {
+		t.Fatalf("readSSHConfigWithoutBlock: %v", err)
+	}
+	if got != "" {
+		t.Errorf("got %q, want empty string", got)
+	}
+}
@@ -0,0 +1,192 @@
+// internal/cli/errors.go
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dba-cli/dba/internal/vm"
+)
+
+// Exit codes returned by the dba binary. 0 and 1 follow the usual Unix
+// convention (success / unspecified failure); everything above that is
+// specific to dba so scripts can branch on it without parsing text.
+const (
+	ExitSuccess     = 0
+	ExitError       = 1
+	ExitUsage       = 2
+	ExitAuth        = 3
+	ExitNotFound    = 4
+	ExitNotReady    = 5
+	ExitNetwork     = 6
+	ExitExecNonZero = 7
+)
+
+// CLIError is a structured error carrying a stable machine-readable Code,
+// the exit code dba should return for it, a human-readable Message, an
+// optional Hint suggesting a fix, and the underlying Cause (if any).
+type CLIError struct {
+	Code     string
+	ExitCode int
+	Message  string
+	Hint     string
+	Cause    error
+}
+
+func (e *CLIError) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+func (e *CLIError) Unwrap() error {
+	return e.Cause
+}
+
+// ErrNotAuthenticated indicates the user has no valid credentials.
+func ErrNotAuthenticated(cause error) *CLIError {
+	return &CLIError{
+		Code:     "not_authenticated",
+		ExitCode: ExitAuth,
+		Message:  "not authenticated",
+		Hint:     "run 'dba auth login' to authenticate",
+		Cause:    cause,
+	}
+}
+
+// ErrTeamNotSet indicates no team slug could be resolved.
+func ErrTeamNotSet(cause error) *CLIError {
+	return &CLIError{
+		Code:     "team_not_set",
+		ExitCode: ExitAuth,
+		Message:  "no team configured",
+		Hint:     "run 'dba auth login' or set DBA_TEAM",
+		Cause:    cause,
+	}
+}
+
+// ErrInstanceNotFound indicates the given instance ID doesn't exist. A
+// missing agent on an existing instance is reported by resolveAgent with
+// its own "agent_not_found" code.
+func ErrInstanceNotFound(instanceID string, cause error) *CLIError {
+	return &CLIError{
+		Code:     "instance_not_found",
+		ExitCode: ExitNotFound,
+		Message:  fmt.Sprintf("instance %q not found", instanceID),
+		Hint:     "run 'dba ls' to see available VMs",
+		Cause:    cause,
+	}
+}
+
+// wrapInstanceErr classifies an error from client.GetInstance (or any
+// other per-instance API call): an actual 404 becomes
+// ErrInstanceNotFound, anything else (network blips, auth hiccups, 5xx)
+// is a transport failure and becomes ErrAPIUnavailable instead, so a
+// flaky connection isn't misreported as a missing VM.
+func wrapInstanceErr(instanceID string, err error) error {
+	if vm.IsNotFound(err) {
+		return ErrInstanceNotFound(instanceID, err)
+	}
+	return ErrAPIUnavailable(err)
+}
+
+// ErrInstanceNotReady indicates the instance exists but can't serve the
+// requested action yet (e.g. no VS Code URL until it finishes booting).
+func ErrInstanceNotReady(instanceID, status string) *CLIError {
+	return &CLIError{
+		Code:     "instance_not_ready",
+		ExitCode: ExitNotReady,
+		Message:  fmt.Sprintf("instance %q is not ready (status: %s)", instanceID, status),
+		Hint:     "run 'dba status " + instanceID + "' to check progress",
+	}
+}
+
+// ErrAPIUnavailable indicates a network/transport failure talking to the API.
+func ErrAPIUnavailable(cause error) *CLIError {
+	return &CLIError{
+		Code:     "api_unavailable",
+		ExitCode: ExitNetwork,
+		Message:  "could not reach the dba API",
+		Hint:     "check your network connection and try again",
+		Cause:    cause,
+	}
+}
+
+// ErrSSHFailed indicates the local ssh/tunnel process exited with an error.
+func ErrSSHFailed(cause error) *CLIError {
+	return &CLIError{
+		Code:     "ssh_failed",
+		ExitCode: ExitExecNonZero,
+		Message:  "ssh session failed",
+		Cause:    cause,
+	}
+}
+
+type errorEnvelope struct {
+	Error errorEnvelopeBody `json:"error"`
+}
+
+type errorEnvelopeBody struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Hint     string `json:"hint,omitempty"`
+	ExitCode int    `json:"exit_code"`
+}
+
+// OutputError prints err to stderr, as a JSON envelope when --json is
+// set and as plain text otherwise. With --verbose, the plain-text output
+// also lists the wrapped Cause chain; the JSON envelope never includes it.
+func OutputError(err error) {
+	if err == nil {
+		return
+	}
+
+	cliErr, ok := err.(*CLIError)
+	if !ok {
+		cliErr = &CLIError{Code: "error", ExitCode: ExitError, Message: err.Error()}
+	}
+
+	if flagJSON {
+		data, marshalErr := json.Marshal(errorEnvelope{Error: errorEnvelopeBody{
+			Code:     cliErr.Code,
+			Message:  cliErr.Message,
+			Hint:     cliErr.Hint,
+			ExitCode: cliErr.ExitCode,
+		}})
+		if marshalErr == nil {
+			fmt.Fprintln(os.Stderr, string(data))
+			return
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: %s\n", cliErr.Message)
+	if cliErr.Hint != "" {
+		fmt.Fprintf(os.Stderr, "Hint: %s\n", cliErr.Hint)
+	}
+	if flagVerbose {
+		for cause := cliErr.Cause; cause != nil; cause = unwrap(cause) {
+			fmt.Fprintf(os.Stderr, "  caused by: %v\n", cause)
+		}
+	}
+}
+
+// GetExitCode maps err to the process exit code dba should return for it.
+func GetExitCode(err error) int {
+	if err == nil {
+		return ExitSuccess
+	}
+	if cliErr, ok := err.(*CLIError); ok {
+		return cliErr.ExitCode
+	}
+	return ExitError
+}
+
+func unwrap(err error) error {
+	u, ok := err.(interface{ Unwrap() error })
+	if !ok {
+		return nil
+	}
+	return u.Unwrap()
+}
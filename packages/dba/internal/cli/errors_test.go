@@ -0,0 +1,58 @@
+// internal/cli/errors_test.go
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestGetExitCode(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitSuccess},
+		{"CLIError", ErrInstanceNotFound("dba_abc123", nil), ExitNotFound},
+		{"plain error", errors.New("boom"), ExitError},
+	}
+
+	for _, c := range cases {
+		if got := GetExitCode(c.err); got != c.want {
+			t.Errorf("%s: GetExitCode() = %d, want %d", c.name, got, c.want)
+		}
+	}
+}
+
+func TestOutputError_JSONEnvelope(t *testing.T) {
+	flagJSON = true
+	defer func() { flagJSON = false }()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	origStderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = origStderr }()
+
+	OutputError(ErrInstanceNotFound("dba_abc123", errors.New("cause")))
+	w.Close()
+
+	var envelope errorEnvelope
+	if decodeErr := json.NewDecoder(r).Decode(&envelope); decodeErr != nil {
+		t.Fatalf("failed to decode error envelope: %v", decodeErr)
+	}
+
+	if envelope.Error.Code != "instance_not_found" {
+		t.Errorf("got code %q, want %q", envelope.Error.Code, "instance_not_found")
+	}
+	if envelope.Error.ExitCode != ExitNotFound {
+		t.Errorf("got exit_code %d, want %d", envelope.Error.ExitCode, ExitNotFound)
+	}
+	if envelope.Error.Message == "" {
+		t.Error("expected a non-empty message")
+	}
+}
@@ -17,32 +17,50 @@ var execCmd = &cobra.Command{
 	Short: "Execute a command in a VM",
 	Long: `Execute a command in a VM.
 
+Use <id>.<agent> to target a specific agent on VMs that expose more
+than one (e.g. dba_abc123.web).
+
 Examples:
   dba exec dba_abc123 "ls -la"
   dba exec dba_abc123 "npm install"
-  dba exec dba_abc123 "cat /etc/os-release"`,
+  dba exec dba_abc123 "cat /etc/os-release"
+  dba exec dba_abc123.web "npm test"`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 		defer cancel()
 
-		instanceID := args[0]
+		t := parseTarget(args[0])
 		command := strings.Join(args[1:], " ")
 
 		teamSlug, err := auth.GetTeamSlug()
 		if err != nil {
-			return fmt.Errorf("failed to get team: %w", err)
+			return ErrTeamNotSet(err)
 		}
 
 		client, err := vm.NewClient()
 		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+			return ErrNotAuthenticated(err)
 		}
 		client.SetTeamSlug(teamSlug)
 
-		stdout, stderr, exitCode, err := client.ExecCommand(ctx, instanceID, command)
+		instance, err := client.GetInstance(ctx, t.InstanceID)
+		if err != nil {
+			return wrapInstanceErr(t.InstanceID, err)
+		}
+
+		agent, err := resolveAgent(instance, t)
+		if err != nil {
+			return err
+		}
+		agentName := ""
+		if agent != nil {
+			agentName = agent.Name
+		}
+
+		stdout, stderr, exitCode, err := client.ExecCommand(ctx, t.InstanceID, agentName, command)
 		if err != nil {
-			return fmt.Errorf("failed to execute command: %w", err)
+			return ErrAPIUnavailable(err)
 		}
 
 		if stdout != "" {
@@ -53,7 +71,11 @@ Examples:
 		}
 
 		if exitCode != 0 {
-			return fmt.Errorf("command exited with code %d", exitCode)
+			return &CLIError{
+				Code:     "command_failed",
+				ExitCode: ExitExecNonZero,
+				Message:  fmt.Sprintf("command exited with code %d", exitCode),
+			}
 		}
 
 		return nil
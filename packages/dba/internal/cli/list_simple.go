@@ -3,6 +3,7 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -17,6 +18,9 @@ var listCmd = &cobra.Command{
 	Short:   "List your VMs",
 	Long: `List all your VM instances.
 
+VMs that expose named agents (see <id>.<agent> addressing) show their
+agents nested beneath them.
+
 Examples:
   dba ls
   dba list`,
@@ -26,18 +30,27 @@ Examples:
 
 		teamSlug, err := auth.GetTeamSlug()
 		if err != nil {
-			return fmt.Errorf("failed to get team: %w", err)
+			return ErrTeamNotSet(err)
 		}
 
 		client, err := vm.NewClient()
 		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+			return ErrNotAuthenticated(err)
 		}
 		client.SetTeamSlug(teamSlug)
 
 		instances, err := client.ListInstances(ctx)
 		if err != nil {
-			return fmt.Errorf("failed to list instances: %w", err)
+			return ErrAPIUnavailable(err)
+		}
+
+		if flagJSON {
+			data, err := json.MarshalIndent(instances, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal instances: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
 		}
 
 		if len(instances) == 0 {
@@ -54,6 +67,14 @@ Examples:
 				url = url[:40] + "..."
 			}
 			fmt.Printf("%-20s %-10s %s\n", inst.ID, inst.Status, url)
+
+			for i, agent := range inst.Agents {
+				branch := "├─"
+				if i == len(inst.Agents)-1 {
+					branch = "└─"
+				}
+				fmt.Printf("  %s %s.%s (%s)\n", branch, inst.ID, agent.Name, agent.Status)
+			}
 		}
 
 		return nil
@@ -0,0 +1,144 @@
+// internal/cli/logs.go
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/dba-cli/dba/internal/auth"
+	"github.com/dba-cli/dba/internal/vm"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logsFlagFollow bool
+	logsFlagSince  string
+	logsFlagTail   int
+	logsFlagGrep   string
+)
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <id>",
+	Short: "Stream logs from a VM",
+	Long: `Stream sandbox logs: the agent's stdout/stderr plus per-process
+logs from previous 'dba exec' invocations.
+
+Use <id>.<agent> to target a specific agent on VMs that expose more
+than one (e.g. dba_abc123.web).
+
+Examples:
+  dba logs dba_abc123
+  dba logs dba_abc123 --tail 200
+  dba logs dba_abc123 --since 15m --follow
+  dba logs dba_abc123 --grep 'ERROR' --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t := parseTarget(args[0])
+
+		since, err := parseLogsSince(logsFlagSince)
+		if err != nil {
+			return &CLIError{Code: "invalid_flag", ExitCode: ExitUsage, Message: err.Error()}
+		}
+
+		var grep *regexp.Regexp
+		if logsFlagGrep != "" {
+			grep, err = regexp.Compile(logsFlagGrep)
+			if err != nil {
+				return &CLIError{Code: "invalid_flag", ExitCode: ExitUsage, Message: fmt.Sprintf("invalid --grep pattern: %v", err)}
+			}
+		}
+
+		teamSlug, err := auth.GetTeamSlug()
+		if err != nil {
+			return ErrTeamNotSet(err)
+		}
+
+		client, err := vm.NewClient()
+		if err != nil {
+			return ErrNotAuthenticated(err)
+		}
+		client.SetTeamSlug(teamSlug)
+
+		fetchCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		instance, err := client.GetInstance(fetchCtx, t.InstanceID)
+		cancel()
+		if err != nil {
+			return wrapInstanceErr(t.InstanceID, err)
+		}
+
+		agent, err := resolveAgent(instance, t)
+		if err != nil {
+			return err
+		}
+		agentName := ""
+		if agent != nil {
+			agentName = agent.Name
+		}
+
+		stream, err := client.Logs(context.Background(), t.InstanceID, agentName, vm.LogOptions{
+			Since: since,
+			Tail:  logsFlagTail,
+		})
+		if err != nil {
+			return ErrAPIUnavailable(err)
+		}
+
+		events, err := stream.Fetch()
+		if err != nil {
+			return ErrAPIUnavailable(err)
+		}
+		for _, ev := range events {
+			printLogEvent(ev, grep)
+		}
+
+		if logsFlagFollow {
+			for ev := range stream.Tail() {
+				printLogEvent(ev, grep)
+			}
+			if err := stream.Err(); err != nil {
+				return ErrAPIUnavailable(err)
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	logsCmd.Flags().BoolVarP(&logsFlagFollow, "follow", "f", false, "Stream new log lines as they arrive")
+	logsCmd.Flags().StringVar(&logsFlagSince, "since", "", "Only show logs newer than this (e.g. 15m, 2h)")
+	logsCmd.Flags().IntVar(&logsFlagTail, "tail", 100, "Number of historical lines to fetch")
+	logsCmd.Flags().StringVar(&logsFlagGrep, "grep", "", "Only show lines matching this regex")
+
+	rootCmd.AddCommand(logsCmd)
+}
+
+func parseLogsSince(raw string) (time.Duration, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --since duration %q: %w", raw, err)
+	}
+	return d, nil
+}
+
+func printLogEvent(ev vm.LogEvent, grep *regexp.Regexp) {
+	if grep != nil && !grep.MatchString(ev.Message) {
+		return
+	}
+
+	if flagJSON {
+		data, err := json.Marshal(ev)
+		if err == nil {
+			fmt.Println(string(data))
+		}
+		return
+	}
+
+	fmt.Printf("%s [%s/%s] %s\n", ev.Timestamp.Format(time.RFC3339), ev.Agent, ev.Stream, ev.Message)
+}
@@ -0,0 +1,35 @@
+// internal/cli/logs_test.go
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLogsSince(t *testing.T) {
+	cases := []struct {
+		raw  string
+		want time.Duration
+	}{
+		{"", 0},
+		{"15m", 15 * time.Minute},
+		{"2h", 2 * time.Hour},
+	}
+
+	for _, c := range cases {
+		got, err := parseLogsSince(c.raw)
+		if err != nil {
+			t.Errorf("parseLogsSince(%q): %v", c.raw, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseLogsSince(%q) = %v, want %v", c.raw, got, c.want)
+		}
+	}
+}
+
+func TestParseLogsSince_Invalid(t *testing.T) {
+	if _, err := parseLogsSince("not-a-duration"); err == nil {
+		t.Error("expected an error for an invalid --since value, got nil")
+	}
+}
@@ -20,37 +20,50 @@ var codeCmd = &cobra.Command{
 	Short: "Open VS Code in browser",
 	Long: `Open VS Code for a VM in your browser.
 
+Use <id>.<agent> to target a specific agent on VMs that expose more
+than one (e.g. dba_abc123.web).
+
 Examples:
-  dba code dba_abc123`,
+  dba code dba_abc123
+  dba code dba_abc123.web`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		instanceID := args[0]
+		t := parseTarget(args[0])
 
 		teamSlug, err := auth.GetTeamSlug()
 		if err != nil {
-			return fmt.Errorf("failed to get team: %w", err)
+			return ErrTeamNotSet(err)
 		}
 
 		client, err := vm.NewClient()
 		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+			return ErrNotAuthenticated(err)
 		}
 		client.SetTeamSlug(teamSlug)
 
-		instance, err := client.GetInstance(ctx, instanceID)
+		instance, err := client.GetInstance(ctx, t.InstanceID)
+		if err != nil {
+			return wrapInstanceErr(t.InstanceID, err)
+		}
+
+		agent, err := resolveAgent(instance, t)
 		if err != nil {
-			return fmt.Errorf("failed to get instance: %w", err)
+			return err
 		}
 
-		if instance.VSCodeURL == "" {
-			return fmt.Errorf("VS Code URL not available")
+		vscodeURL := instance.VSCodeURL
+		if agent != nil {
+			vscodeURL = agent.VSCodeURL
+		}
+		if vscodeURL == "" {
+			return ErrInstanceNotReady(t.InstanceID, instance.Status)
 		}
 
-		fmt.Printf("Opening VS Code: %s\n", instance.VSCodeURL)
-		return openBrowser(instance.VSCodeURL)
+		fmt.Printf("Opening VS Code: %s\n", vscodeURL)
+		return openBrowser(vscodeURL)
 	},
 }
 
@@ -59,68 +72,102 @@ var vncCmd = &cobra.Command{
 	Short: "Open VNC desktop in browser",
 	Long: `Open VNC desktop for a VM in your browser.
 
+Use <id>.<agent> to target a specific agent on VMs that expose more
+than one (e.g. dba_abc123.web).
+
 Examples:
-  dba vnc dba_abc123`,
+  dba vnc dba_abc123
+  dba vnc dba_abc123.web`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		instanceID := args[0]
+		t := parseTarget(args[0])
 
 		teamSlug, err := auth.GetTeamSlug()
 		if err != nil {
-			return fmt.Errorf("failed to get team: %w", err)
+			return ErrTeamNotSet(err)
 		}
 
 		client, err := vm.NewClient()
 		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+			return ErrNotAuthenticated(err)
 		}
 		client.SetTeamSlug(teamSlug)
 
-		instance, err := client.GetInstance(ctx, instanceID)
+		instance, err := client.GetInstance(ctx, t.InstanceID)
 		if err != nil {
-			return fmt.Errorf("failed to get instance: %w", err)
+			return wrapInstanceErr(t.InstanceID, err)
 		}
 
-		if instance.VNCURL == "" {
-			return fmt.Errorf("VNC URL not available")
+		agent, err := resolveAgent(instance, t)
+		if err != nil {
+			return err
 		}
 
-		fmt.Printf("Opening VNC: %s\n", instance.VNCURL)
-		return openBrowser(instance.VNCURL)
+		vncURL := instance.VNCURL
+		if agent != nil {
+			vncURL = agent.VNCURL
+		}
+		if vncURL == "" {
+			return ErrInstanceNotReady(t.InstanceID, instance.Status)
+		}
+
+		fmt.Printf("Opening VNC: %s\n", vncURL)
+		return openBrowser(vncURL)
 	},
 }
 
+var sshFlagWS bool
+
 var sshCmd = &cobra.Command{
 	Use:   "ssh <id>",
 	Short: "SSH into a VM",
 	Long: `SSH into a VM.
 
+Use <id>.<agent> to target a specific agent on VMs that expose more
+than one (e.g. dba_abc123.web).
+
 Examples:
-  dba ssh dba_abc123`,
+  dba ssh dba_abc123
+  dba ssh dba_abc123.web
+  dba ssh --ws dba_abc123   # tunnel over WebSocket (for networks that block port 22)`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		instanceID := args[0]
+		t := parseTarget(args[0])
 
 		teamSlug, err := auth.GetTeamSlug()
 		if err != nil {
-			return fmt.Errorf("failed to get team: %w", err)
+			return ErrTeamNotSet(err)
 		}
 
 		client, err := vm.NewClient()
 		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+			return ErrNotAuthenticated(err)
 		}
 		client.SetTeamSlug(teamSlug)
 
-		sshCommand, err := client.GetSSHCredentials(ctx, instanceID)
+		instance, err := client.GetInstance(ctx, t.InstanceID)
+		if err != nil {
+			return wrapInstanceErr(t.InstanceID, err)
+		}
+
+		agent, err := resolveAgent(instance, t)
 		if err != nil {
-			return fmt.Errorf("failed to get SSH credentials: %w", err)
+			return err
+		}
+		agentName := ""
+		if agent != nil {
+			agentName = agent.Name
+		}
+
+		sshCommand, err := client.GetSSHCredentials(ctx, t.InstanceID, agentName)
+		if err != nil {
+			return wrapInstanceErr(t.InstanceID, err)
 		}
 
 		fmt.Printf("Connecting: %s\n", sshCommand)
@@ -128,19 +175,27 @@ Examples:
 		// Parse SSH command: "ssh token@ssh.cloud.morph.so"
 		parts := strings.Fields(sshCommand)
 		if len(parts) < 2 {
-			return fmt.Errorf("invalid SSH command format")
+			return &CLIError{Code: "invalid_ssh_response", ExitCode: ExitError, Message: "invalid SSH command format"}
 		}
 
-		sshExec := exec.Command("ssh",
+		sshArgs := []string{
 			"-o", "StrictHostKeyChecking=no",
 			"-o", "UserKnownHostsFile=/dev/null",
-			parts[1],
-		)
+		}
+		if sshFlagWS {
+			sshArgs = append(sshArgs, "-o", "ProxyCommand="+tunnelProxyCommand(args[0]))
+		}
+		sshArgs = append(sshArgs, parts[1])
+
+		sshExec := exec.Command("ssh", sshArgs...)
 		sshExec.Stdin = os.Stdin
 		sshExec.Stdout = os.Stdout
 		sshExec.Stderr = os.Stderr
 
-		return sshExec.Run()
+		if err := sshExec.Run(); err != nil {
+			return ErrSSHFailed(err)
+		}
+		return nil
 	},
 }
 
@@ -149,38 +204,52 @@ var statusCmd = &cobra.Command{
 	Short: "Show VM status",
 	Long: `Show the status of a VM.
 
+Use <id>.<agent> to target a specific agent on VMs that expose more
+than one (e.g. dba_abc123.web).
+
 Examples:
-  dba status dba_abc123`,
+  dba status dba_abc123
+  dba status dba_abc123.web`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		instanceID := args[0]
+		t := parseTarget(args[0])
 
 		teamSlug, err := auth.GetTeamSlug()
 		if err != nil {
-			return fmt.Errorf("failed to get team: %w", err)
+			return ErrTeamNotSet(err)
 		}
 
 		client, err := vm.NewClient()
 		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+			return ErrNotAuthenticated(err)
 		}
 		client.SetTeamSlug(teamSlug)
 
-		instance, err := client.GetInstance(ctx, instanceID)
+		instance, err := client.GetInstance(ctx, t.InstanceID)
 		if err != nil {
-			return fmt.Errorf("failed to get instance: %w", err)
+			return wrapInstanceErr(t.InstanceID, err)
+		}
+
+		agent, err := resolveAgent(instance, t)
+		if err != nil {
+			return err
 		}
 
 		fmt.Printf("ID:       %s\n", instance.ID)
 		fmt.Printf("Status:   %s\n", instance.Status)
-		if instance.VSCodeURL != "" {
-			fmt.Printf("VS Code:  %s\n", instance.VSCodeURL)
+		vscodeURL, vncURL := instance.VSCodeURL, instance.VNCURL
+		if agent != nil {
+			fmt.Printf("Agent:    %s (%s)\n", agent.Name, agent.Status)
+			vscodeURL, vncURL = agent.VSCodeURL, agent.VNCURL
+		}
+		if vscodeURL != "" {
+			fmt.Printf("VS Code:  %s\n", vscodeURL)
 		}
-		if instance.VNCURL != "" {
-			fmt.Printf("VNC:      %s\n", instance.VNCURL)
+		if vncURL != "" {
+			fmt.Printf("VNC:      %s\n", vncURL)
 		}
 
 		return nil
@@ -205,6 +274,8 @@ func openBrowser(url string) error {
 }
 
 func init() {
+	sshCmd.Flags().BoolVar(&sshFlagWS, "ws", false, "Tunnel SSH over WebSocket instead of connecting directly on port 22")
+
 	rootCmd.AddCommand(codeCmd)
 	rootCmd.AddCommand(vncCmd)
 	rootCmd.AddCommand(sshCmd)
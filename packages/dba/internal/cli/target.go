@@ -0,0 +1,69 @@
+// internal/cli/target.go
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dba-cli/dba/internal/vm"
+)
+
+// target identifies a VM and, optionally, one of the named agents it
+// exposes (e.g. "dba_abc123.web" targets the "web" agent on dba_abc123).
+type target struct {
+	InstanceID string
+	Agent      string
+}
+
+// parseTarget splits a CLI positional argument of the form <id> or
+// <id>.<agent> into its instance ID and agent name. Agent is empty when
+// the argument names a bare instance ID.
+func parseTarget(raw string) target {
+	if idx := strings.LastIndex(raw, "."); idx != -1 {
+		return target{InstanceID: raw[:idx], Agent: raw[idx+1:]}
+	}
+	return target{InstanceID: raw}
+}
+
+// resolveAgent picks the agent t refers to out of instance.Agents.
+//
+// If instance exposes no agents, it returns (nil, nil) - the instance
+// predates multi-agent support and should be treated as a single target.
+// If t names an agent, that agent is returned or an error if it doesn't
+// exist. If t doesn't name one, a single agent is picked automatically;
+// with more than one, the available names are printed and an error is
+// returned so the caller can re-run with <id>.<agent>.
+func resolveAgent(instance *vm.Instance, t target) (*vm.Agent, error) {
+	if len(instance.Agents) == 0 {
+		return nil, nil
+	}
+
+	if t.Agent != "" {
+		for i := range instance.Agents {
+			if instance.Agents[i].Name == t.Agent {
+				return &instance.Agents[i], nil
+			}
+		}
+		return nil, &CLIError{
+			Code:     "agent_not_found",
+			ExitCode: ExitNotFound,
+			Message:  fmt.Sprintf("agent %q not found on %s", t.Agent, t.InstanceID),
+			Hint:     fmt.Sprintf("run 'dba status %s' to see its agents", t.InstanceID),
+		}
+	}
+
+	if len(instance.Agents) == 1 {
+		return &instance.Agents[0], nil
+	}
+
+	names := make([]string, len(instance.Agents))
+	for i, a := range instance.Agents {
+		names[i] = t.InstanceID + "." + a.Name
+	}
+	return nil, &CLIError{
+		Code:     "ambiguous_agent",
+		ExitCode: ExitUsage,
+		Message:  fmt.Sprintf("%s has multiple agents, specify one", t.InstanceID),
+		Hint:     "available: " + strings.Join(names, ", "),
+	}
+}
@@ -0,0 +1,101 @@
+// internal/cli/target_test.go
+package cli
+
+import (
+	"testing"
+
+	"github.com/dba-cli/dba/internal/vm"
+)
+
+func TestParseTarget(t *testing.T) {
+	cases := []struct {
+		raw      string
+		wantID   string
+		wantName string
+	}{
+		{"dba_abc123", "dba_abc123", ""},
+		{"dba_abc123.web", "dba_abc123", "web"},
+		{"dba_abc123.web.db", "dba_abc123.web", "db"},
+	}
+
+	for _, c := range cases {
+		got := parseTarget(c.raw)
+		if got.InstanceID != c.wantID || got.Agent != c.wantName {
+			t.Errorf("parseTarget(%q) = {%q, %q}, want {%q, %q}", c.raw, got.InstanceID, got.Agent, c.wantID, c.wantName)
+		}
+	}
+}
+
+func TestResolveAgent_NoAgents(t *testing.T) {
+	instance := &vm.Instance{ID: "dba_abc123"}
+
+	agent, err := resolveAgent(instance, target{InstanceID: "dba_abc123"})
+	if err != nil {
+		t.Fatalf("resolveAgent: %v", err)
+	}
+	if agent != nil {
+		t.Errorf("got agent %+v, want nil", agent)
+	}
+}
+
+func TestResolveAgent_SingleAgentPickedAutomatically(t *testing.T) {
+	instance := &vm.Instance{
+		ID:     "dba_abc123",
+		Agents: []vm.Agent{{Name: "web"}},
+	}
+
+	agent, err := resolveAgent(instance, target{InstanceID: "dba_abc123"})
+	if err != nil {
+		t.Fatalf("resolveAgent: %v", err)
+	}
+	if agent == nil || agent.Name != "web" {
+		t.Errorf("got %+v, want agent \"web\"", agent)
+	}
+}
+
+func TestResolveAgent_Ambiguous(t *testing.T) {
+	instance := &vm.Instance{
+		ID:     "dba_abc123",
+		Agents: []vm.Agent{{Name: "web"}, {Name: "db"}},
+	}
+
+	_, err := resolveAgent(instance, target{InstanceID: "dba_abc123"})
+	if err == nil {
+		t.Fatal("expected an error for an ambiguous agent, got nil")
+	}
+	cliErr, ok := err.(*CLIError)
+	if !ok || cliErr.Code != "ambiguous_agent" {
+		t.Errorf("got %#v, want a CLIError with code \"ambiguous_agent\"", err)
+	}
+}
+
+func TestResolveAgent_NotFound(t *testing.T) {
+	instance := &vm.Instance{
+		ID:     "dba_abc123",
+		Agents: []vm.Agent{{Name: "web"}},
+	}
+
+	_, err := resolveAgent(instance, target{InstanceID: "dba_abc123", Agent: "missing"})
+	if err == nil {
+		t.Fatal("expected an error for a missing agent, got nil")
+	}
+	cliErr, ok := err.(*CLIError)
+	if !ok || cliErr.Code != "agent_not_found" {
+		t.Errorf("got %#v, want a CLIError with code \"agent_not_found\"", err)
+	}
+}
+
+func TestResolveAgent_NamedAgentFound(t *testing.T) {
+	instance := &vm.Instance{
+		ID:     "dba_abc123",
+		Agents: []vm.Agent{{Name: "web"}, {Name: "db"}},
+	}
+
+	agent, err := resolveAgent(instance, target{InstanceID: "dba_abc123", Agent: "db"})
+	if err != nil {
+		t.Fatalf("resolveAgent: %v", err)
+	}
+	if agent == nil || agent.Name != "db" {
+		t.Errorf("got %+v, want agent \"db\"", agent)
+	}
+}
@@ -0,0 +1,201 @@
+// internal/cli/tunnel.go
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	"github.com/dba-cli/dba/internal/auth"
+	"github.com/dba-cli/dba/internal/vm"
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/spf13/cobra"
+)
+
+const tunnelFrameSize = 16384
+
+var (
+	tunnelFlagLocal int
+	tunnelFlagStdio bool
+)
+
+var tunnelCmd = &cobra.Command{
+	Use:   "tunnel <id>",
+	Short: "Tunnel SSH to a VM over WebSocket",
+	Long: `Tunnel SSH to a VM over WebSocket instead of connecting directly on
+port 22. This lets ssh reach a VM on networks that only allow outbound
+HTTPS (443), without running a separate ws-ssh-proxy binary.
+
+Examples:
+  dba tunnel dba_abc123 --local 2222
+  ssh -o ProxyCommand="dba tunnel %h --stdio" dba_abc123`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		t := parseTarget(args[0])
+
+		wsURL, err := sshProxyURL(t)
+		if err != nil {
+			return err
+		}
+
+		if tunnelFlagStdio {
+			return runStdioTunnel(wsURL)
+		}
+		return runListenerTunnel(wsURL, tunnelFlagLocal)
+	},
+}
+
+func init() {
+	tunnelCmd.Flags().IntVar(&tunnelFlagLocal, "local", 0, "Local TCP port to listen on (0 picks a free port)")
+	tunnelCmd.Flags().BoolVar(&tunnelFlagStdio, "stdio", false, "Speak the tunnel protocol on stdin/stdout, for use as an ssh ProxyCommand")
+
+	rootCmd.AddCommand(tunnelCmd)
+}
+
+// sshProxyURL resolves the wss:// endpoint that bridges to the VM's (or
+// agent's) SSH port, as reported by the API's ssh-proxy-url endpoint
+// (dba ssh --ws reaches it through tunnelProxyCommand).
+func sshProxyURL(t target) (string, error) {
+	teamSlug, err := auth.GetTeamSlug()
+	if err != nil {
+		return "", ErrTeamNotSet(err)
+	}
+
+	client, err := vm.NewClient()
+	if err != nil {
+		return "", ErrNotAuthenticated(err)
+	}
+	client.SetTeamSlug(teamSlug)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	instance, err := client.GetInstance(ctx, t.InstanceID)
+	if err != nil {
+		return "", wrapInstanceErr(t.InstanceID, err)
+	}
+
+	agent, err := resolveAgent(instance, t)
+	if err != nil {
+		return "", err
+	}
+	agentName := ""
+	if agent != nil {
+		agentName = agent.Name
+	}
+
+	url, err := client.GetSSHProxyURL(ctx, t.InstanceID, agentName)
+	if err != nil {
+		return "", ErrAPIUnavailable(err)
+	}
+	return url, nil
+}
+
+// tunnelProxyCommand returns the ssh ProxyCommand string that routes
+// through this binary's own `tunnel --stdio` mode.
+func tunnelProxyCommand(instanceID string) string {
+	self, err := os.Executable()
+	if err != nil {
+		self = "dba"
+	}
+	return fmt.Sprintf("%s tunnel %s --stdio", self, instanceID)
+}
+
+// runStdioTunnel dials the WebSocket endpoint and bridges it to this
+// process's stdin/stdout, for use as an ssh ProxyCommand.
+func runStdioTunnel(wsURL string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	conn, _, _, err := ws.Dial(ctx, wsURL)
+	cancel()
+	if err != nil {
+		return ErrAPIUnavailable(err)
+	}
+	defer conn.Close()
+
+	if err := bridgeWebSocket(conn, os.Stdin, os.Stdout); err != nil && err != io.EOF {
+		return ErrSSHFailed(err)
+	}
+	return nil
+}
+
+// runListenerTunnel listens on a local TCP port and bridges every
+// accepted connection to its own WebSocket dial, so tools that can't
+// invoke a ProxyCommand directly (rsync, scp -P, IDE remotes) can just
+// point at 127.0.0.1:<port>.
+func runListenerTunnel(wsURL string, port int) error {
+	ln, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return &CLIError{Code: "listen_failed", ExitCode: ExitError, Message: "failed to listen locally", Cause: err}
+	}
+	defer ln.Close()
+
+	fmt.Printf("Listening on %s, forwarding to %s\n", ln.Addr(), wsURL)
+
+	for {
+		local, err := ln.Accept()
+		if err != nil {
+			return &CLIError{Code: "accept_failed", ExitCode: ExitError, Message: "failed to accept connection", Cause: err}
+		}
+
+		go func() {
+			defer local.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			remote, _, _, err := ws.Dial(ctx, wsURL)
+			cancel()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "dial WebSocket proxy: %v\n", err)
+				return
+			}
+			defer remote.Close()
+
+			if err := bridgeWebSocket(remote, local, local); err != nil && err != io.EOF {
+				fmt.Fprintf(os.Stderr, "tunnel closed: %v\n", err)
+			}
+		}()
+	}
+}
+
+// bridgeWebSocket copies r into wsConn as binary frames and copies binary
+// frames from wsConn into w, mirroring the 16 KiB framing ws-ssh-proxy
+// uses on the server side. It returns when either direction errors.
+func bridgeWebSocket(wsConn net.Conn, r io.Reader, w io.Writer) error {
+	errCh := make(chan error, 2)
+
+	go func() {
+		buf := make([]byte, tunnelFrameSize)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				if werr := wsutil.WriteClientBinary(wsConn, buf[:n]); werr != nil {
+					errCh <- werr
+					return
+				}
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	go func() {
+		for {
+			data, err := wsutil.ReadServerBinary(wsConn)
+			if err != nil {
+				errCh <- err
+				return
+			}
+			if _, werr := w.Write(data); werr != nil {
+				errCh <- werr
+				return
+			}
+		}
+	}()
+
+	return <-errCh
+}
@@ -0,0 +1,174 @@
+// internal/vm/client.go
+package vm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/dba-cli/dba/internal/auth"
+)
+
+const defaultAPIURL = "https://api.dba.dev"
+
+// Client talks to the dba control-plane API on behalf of one team.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	token      string
+	teamSlug   string
+}
+
+// NewClient builds a Client authenticated with the token from
+// auth.GetToken, pointed at DBA_API_URL (or the production API if unset).
+func NewClient() (*Client, error) {
+	token, err := auth.GetToken()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := os.Getenv("DBA_API_URL")
+	if baseURL == "" {
+		baseURL = defaultAPIURL
+	}
+
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    baseURL,
+		token:      token,
+	}, nil
+}
+
+// SetTeamSlug scopes every subsequent request to team.
+func (c *Client) SetTeamSlug(team string) {
+	c.teamSlug = team
+}
+
+// ListInstances returns every VM the current team owns.
+func (c *Client) ListInstances(ctx context.Context) ([]Instance, error) {
+	var instances []Instance
+	if err := c.do(ctx, http.MethodGet, "/v1/instances", nil, &instances); err != nil {
+		return nil, err
+	}
+	return instances, nil
+}
+
+// GetInstance fetches a single instance by ID.
+func (c *Client) GetInstance(ctx context.Context, instanceID string) (*Instance, error) {
+	var instance Instance
+	path := fmt.Sprintf("/v1/instances/%s", url.PathEscape(instanceID))
+	if err := c.do(ctx, http.MethodGet, path, nil, &instance); err != nil {
+		return nil, err
+	}
+	return &instance, nil
+}
+
+// GetSSHCredentials returns a ready-to-run ssh command ("ssh
+// token@host") for instanceID, scoped to agentName when it's non-empty.
+func (c *Client) GetSSHCredentials(ctx context.Context, instanceID, agentName string) (string, error) {
+	path := fmt.Sprintf("/v1/instances/%s/ssh-credentials", url.PathEscape(instanceID))
+	if agentName != "" {
+		path += "?agent=" + url.QueryEscape(agentName)
+	}
+
+	var resp struct {
+		Command string `json:"command"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.Command, nil
+}
+
+// GetSSHProxyURL returns the wss:// endpoint that bridges WebSocket
+// frames to instanceID's (or agentName's) SSH port, for use by `dba
+// tunnel` and `dba ssh --ws`.
+func (c *Client) GetSSHProxyURL(ctx context.Context, instanceID, agentName string) (string, error) {
+	path := fmt.Sprintf("/v1/instances/%s/ssh-proxy-url", url.PathEscape(instanceID))
+	if agentName != "" {
+		path += "?agent=" + url.QueryEscape(agentName)
+	}
+
+	var resp struct {
+		URL string `json:"url"`
+	}
+	if err := c.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return "", err
+	}
+	return resp.URL, nil
+}
+
+// ExecCommand runs command on instanceID (scoped to agentName when
+// non-empty) and returns its captured stdout, stderr, and exit code.
+func (c *Client) ExecCommand(ctx context.Context, instanceID, agentName, command string) (stdout, stderr string, exitCode int, err error) {
+	path := fmt.Sprintf("/v1/instances/%s/exec", url.PathEscape(instanceID))
+
+	reqBody := struct {
+		Agent   string `json:"agent,omitempty"`
+		Command string `json:"command"`
+	}{Agent: agentName, Command: command}
+
+	var resp struct {
+		Stdout   string `json:"stdout"`
+		Stderr   string `json:"stderr"`
+		ExitCode int    `json:"exit_code"`
+	}
+	if err := c.do(ctx, http.MethodPost, path, reqBody, &resp); err != nil {
+		return "", "", 0, err
+	}
+	return resp.Stdout, resp.Stderr, resp.ExitCode, nil
+}
+
+// do issues an authenticated JSON request against path and decodes the
+// response body into out (if non-nil). A non-2xx response becomes an
+// *APIError carrying the status code, so callers can use IsNotFound to
+// tell a real 404 apart from a transient failure.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var bodyReader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("X-Team-Slug", c.teamSlug)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{StatusCode: resp.StatusCode, Message: string(bytes.TrimSpace(data))}
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
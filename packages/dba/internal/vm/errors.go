@@ -0,0 +1,30 @@
+// internal/vm/errors.go
+package vm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// APIError is returned for any non-2xx response from the dba API, and
+// carries the HTTP status code so callers (and IsNotFound) can tell a
+// real 404 apart from a network blip or a 5xx.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error (%d): %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an APIError for an actual HTTP 404,
+// as opposed to a transient network/auth/server failure that merely
+// looks like one from the caller's perspective.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == 404
+	}
+	return false
+}
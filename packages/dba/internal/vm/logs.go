@@ -0,0 +1,228 @@
+// internal/vm/logs.go
+package vm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+// LogOptions bounds the historical window Client.Logs fetches before
+// (optionally) following new lines as they arrive.
+type LogOptions struct {
+	// Since only returns log lines newer than this long ago. Zero means
+	// no lower bound.
+	Since time.Duration
+	// Tail caps the number of historical lines returned. Zero means the
+	// server's default.
+	Tail int
+}
+
+// LogEvent is one line of sandbox output.
+type LogEvent struct {
+	Timestamp time.Time `json:"ts"`
+	Agent     string    `json:"agent"`
+	Stream    string    `json:"stream"`
+	Message   string    `json:"msg"`
+}
+
+// LogStream is a bounded historical window (Fetch) plus an optional
+// live tail (Tail) of a sandbox's logs.
+type LogStream interface {
+	// Fetch returns the historical window requested via LogOptions.
+	Fetch() ([]LogEvent, error)
+	// Tail streams new log lines as they arrive. Dial and read errors
+	// are retried, so the channel is closed only once ctx is canceled;
+	// check Err afterward.
+	Tail() <-chan LogEvent
+	// Err returns the most recent dial or read error seen while
+	// tailing, if any.
+	Err() error
+}
+
+const (
+	logsReconnectMin = 50 * time.Millisecond
+	logsReconnectMax = 10 * time.Second
+)
+
+// Logs opens a log stream for instanceID (scoped to agentName when
+// non-empty): it fetches the historical window described by opts up
+// front, and lazily starts tailing new lines over a WebSocket - with
+// exponential backoff (50ms -> 10s) across reconnects - the first time
+// Tail is called.
+func (c *Client) Logs(ctx context.Context, instanceID, agentName string, opts LogOptions) (LogStream, error) {
+	history, err := c.fetchLogHistory(ctx, instanceID, agentName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	wsURL, err := c.logsWebSocketURL(instanceID, agentName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wsLogStream{
+		ctx:     ctx,
+		wsURL:   wsURL,
+		history: history,
+	}, nil
+}
+
+func (c *Client) fetchLogHistory(ctx context.Context, instanceID, agentName string, opts LogOptions) ([]LogEvent, error) {
+	path := fmt.Sprintf("/v1/instances/%s/logs", url.PathEscape(instanceID))
+
+	q := url.Values{}
+	if agentName != "" {
+		q.Set("agent", agentName)
+	}
+	if opts.Tail > 0 {
+		q.Set("tail", strconv.Itoa(opts.Tail))
+	}
+	if opts.Since > 0 {
+		q.Set("since", opts.Since.String())
+	}
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+
+	var events []LogEvent
+	if err := c.do(ctx, "GET", path, nil, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (c *Client) logsWebSocketURL(instanceID, agentName string) (string, error) {
+	u, err := url.Parse(c.baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid API URL: %w", err)
+	}
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = fmt.Sprintf("/v1/instances/%s/logs/stream", url.PathEscape(instanceID))
+
+	q := url.Values{}
+	q.Set("token", c.token)
+	if agentName != "" {
+		q.Set("agent", agentName)
+	}
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// wsLogStream implements LogStream by replaying a prefetched history
+// and, once Tail is called, dialing a WebSocket that reconnects with
+// exponential backoff until ctx is canceled.
+type wsLogStream struct {
+	ctx     context.Context
+	wsURL   string
+	history []LogEvent
+
+	tailOnce sync.Once
+	events   chan LogEvent
+
+	mu  sync.Mutex
+	err error
+}
+
+func (s *wsLogStream) Fetch() ([]LogEvent, error) {
+	return s.history, nil
+}
+
+func (s *wsLogStream) Tail() <-chan LogEvent {
+	s.tailOnce.Do(func() {
+		s.events = make(chan LogEvent, 64)
+		go s.run()
+	})
+	return s.events
+}
+
+func (s *wsLogStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *wsLogStream) setErr(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// run dials the log WebSocket and reads frames until the connection
+// errors, reconnecting with exponential backoff (50ms -> 10s cap) until
+// ctx is canceled.
+func (s *wsLogStream) run() {
+	defer close(s.events)
+
+	backoff := logsReconnectMin
+	for s.ctx.Err() == nil {
+		conn, _, _, err := ws.Dial(s.ctx, s.wsURL)
+		if err != nil {
+			s.setErr(fmt.Errorf("dial log stream: %w", err))
+			if !s.sleepBackoff(&backoff) {
+				return
+			}
+			continue
+		}
+
+		backoff = logsReconnectMin
+		err = s.readLoop(conn)
+		conn.Close()
+		if err != nil {
+			s.setErr(err)
+		}
+
+		if !s.sleepBackoff(&backoff) {
+			return
+		}
+	}
+}
+
+// readLoop decodes one JSON LogEvent per text frame from conn, pushing
+// each onto s.events, until conn errors or ctx is canceled.
+func (s *wsLogStream) readLoop(conn net.Conn) error {
+	for {
+		data, err := wsutil.ReadServerText(conn)
+		if err != nil {
+			return err
+		}
+
+		var ev LogEvent
+		if err := json.Unmarshal(data, &ev); err != nil {
+			continue
+		}
+
+		select {
+		case s.events <- ev:
+		case <-s.ctx.Done():
+			return s.ctx.Err()
+		}
+	}
+}
+
+func (s *wsLogStream) sleepBackoff(backoff *time.Duration) bool {
+	select {
+	case <-s.ctx.Done():
+		return false
+	case <-time.After(*backoff):
+	}
+	*backoff *= 2
+	if *backoff > logsReconnectMax {
+		*backoff = logsReconnectMax
+	}
+	return true
+}
@@ -0,0 +1,22 @@
+// internal/vm/types.go
+package vm
+
+// Instance is a single sandbox VM.
+type Instance struct {
+	ID        string  `json:"id"`
+	Status    string  `json:"status"`
+	VSCodeURL string  `json:"vscode_url,omitempty"`
+	VNCURL    string  `json:"vnc_url,omitempty"`
+	Agents    []Agent `json:"agents,omitempty"`
+}
+
+// Agent is one named agent exposed by an Instance (e.g. a "web" and a
+// "db" process sharing a single VM). Addressed from the CLI as
+// "<id>.<agent>".
+type Agent struct {
+	Name      string `json:"name"`
+	SSHPort   int    `json:"ssh_port,omitempty"`
+	VSCodeURL string `json:"vscode_url,omitempty"`
+	VNCURL    string `json:"vnc_url,omitempty"`
+	Status    string `json:"status"`
+}